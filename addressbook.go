@@ -0,0 +1,75 @@
+package multicall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// multicall3Address is the canonical Multicall3 deployment address, reused
+// on every chain it has been deployed to.
+// https://www.multicall3.com/deployments
+const multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// addressBook maps chain IDs to their Multicall3 deployment address. Entries
+// come from the official deployments list at https://www.multicall3.com/deployments.
+// Almost all chains share multicall3Address; chains using a non-standard
+// address are listed explicitly.
+var addressBook = map[uint64]common.Address{
+	1:        common.HexToAddress(multicall3Address), // Ethereum Mainnet
+	5:        common.HexToAddress(multicall3Address), // Goerli
+	10:       common.HexToAddress(multicall3Address), // Optimism
+	56:       common.HexToAddress(multicall3Address), // BNB Smart Chain
+	97:       common.HexToAddress(multicall3Address), // BNB Smart Chain Testnet
+	100:      common.HexToAddress(multicall3Address), // Gnosis Chain
+	137:      common.HexToAddress(multicall3Address), // Polygon
+	250:      common.HexToAddress(multicall3Address), // Fantom
+	420:      common.HexToAddress(multicall3Address), // Optimism Goerli
+	42161:    common.HexToAddress(multicall3Address), // Arbitrum One
+	42170:    common.HexToAddress(multicall3Address), // Arbitrum Nova
+	421613:   common.HexToAddress(multicall3Address), // Arbitrum Goerli
+	43114:    common.HexToAddress(multicall3Address), // Avalanche
+	80001:    common.HexToAddress(multicall3Address), // Polygon Mumbai
+	11155111: common.HexToAddress(multicall3Address), // Sepolia
+}
+
+// MulticallAddress returns the Multicall3 deployment address for chainID.
+//
+// Returns:
+//   - common.Address: The Multicall3 address on chainID
+//   - error: An error if chainID is not in the address book
+func MulticallAddress(chainID uint64) (common.Address, error) {
+	address, ok := addressBook[chainID]
+	if !ok {
+		return common.Address{}, fmt.Errorf("no known Multicall3 deployment for chain ID %d", chainID)
+	}
+	return address, nil
+}
+
+// NewMulticallConfigAuto creates a new reusable multicall config, looking up
+// client's chain ID and resolving the Multicall3 address for it automatically
+// instead of requiring the caller to hardcode it.
+//
+// Parameters:
+//   - ctx: The context to use for the chain ID lookup
+//   - client: The Ethereum client to use. Accepts *MultiClient as well as
+//     *ethclient.Client, so auto chain-ID/address detection can be combined
+//     with multi-endpoint failover.
+//
+// Returns:
+//   - *MulticallConfig: The multicall config, with default BatchSize and Timeout
+//   - error: An error if the chain ID lookup fails, or the chain has no known Multicall3 deployment
+func NewMulticallConfigAuto(ctx context.Context, client EthCaller) (*MulticallConfig, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	address, err := MulticallAddress(chainID.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDefaultMulticallConfig(client, address), nil
+}
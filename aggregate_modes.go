@@ -0,0 +1,252 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MulticallRawValue is identical to MulticallRaw, except each call may carry
+// its own msg.value via CallRequestValue. This is executed through the
+// Multicall3 `aggregate3Value` method instead of `aggregate3`; per the
+// Multicall3 contract, the transaction's msg.value must equal the sum of
+// every call's Value in the batch, which this sums and sets automatically.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute.
+//
+// Returns:
+//   - []CallResult: Packed results and success status of the calls
+//   - error: An error if the multicall fails
+func MulticallRawValue(
+	cfg *MulticallConfig,
+	calls []CallRequestValue,
+) ([]CallResult, error) {
+	results := make([]CallResult, len(calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	err := executeBatches(ctx, cfg, calls,
+		func(batch []CallRequestValue) ([]byte, *big.Int, error) {
+			// A nil Value means "no value", but abi.Pack requires a non-nil
+			// *big.Int for the uint256 Call3Value.value field, so normalize
+			// nil to zero before packing, same as the summing below treats it.
+			total := new(big.Int)
+			packedBatch := make([]CallRequestValue, len(batch))
+			for i, call := range batch {
+				if call.Value != nil {
+					total.Add(total, call.Value)
+				} else {
+					call.Value = new(big.Int)
+				}
+				packedBatch[i] = call
+			}
+
+			data, err := multicallABI.Pack("aggregate3Value", packedBatch)
+			if err != nil {
+				return nil, nil, err
+			}
+			return data, total, nil
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			return cfg.Client.CallContract(ctx, callMsg, nil)
+		},
+		func(start int, raw []byte) error {
+			var response []CallResult
+			if err := multicallABI.UnpackIntoInterface(&response, "aggregate3Value", raw); err != nil {
+				return err
+			}
+			for j := range response {
+				results[start+j] = response[j]
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// TryAggregate executes calls through the legacy Multicall `tryAggregate`
+// method. Unlike aggregate3/aggregate3Value, per-call failure tolerance is
+// not configurable per call: requireSuccess applies to the whole batch, and
+// a single reverting call with requireSuccess set to true reverts the
+// transaction.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - requireSuccess: Whether every call in the batch must succeed
+//   - calls: The calls to execute.
+//
+// Returns:
+//   - []CallResult: Packed results and success status of the calls
+//   - error: An error if the multicall fails
+func TryAggregate(
+	cfg *MulticallConfig,
+	requireSuccess bool,
+	calls []LegacyCallRequest,
+) ([]CallResult, error) {
+	results := make([]CallResult, len(calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	err := executeBatches(ctx, cfg, calls,
+		func(batch []LegacyCallRequest) ([]byte, *big.Int, error) {
+			data, err := multicallABI.Pack("tryAggregate", requireSuccess, batch)
+			return data, nil, err
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			return cfg.Client.CallContract(ctx, callMsg, nil)
+		},
+		func(start int, raw []byte) error {
+			var response []CallResult
+			if err := multicallABI.UnpackIntoInterface(&response, "tryAggregate", raw); err != nil {
+				return err
+			}
+			for j := range response {
+				results[start+j] = response[j]
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Aggregate executes calls through the Multicall v1 `aggregate` method, for
+// chains where only the original Multicall deployment is available. There is
+// no per-call failure tolerance: any reverting call reverts the whole batch.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute.
+//
+// Returns:
+//   - blockNumber: The block the calls were executed against. If calls span
+//     more than one batch, this is the block of the last batch to complete.
+//   - returnData: The raw return data of each call, in order
+//   - error: An error if the multicall fails
+func Aggregate(
+	cfg *MulticallConfig,
+	calls []LegacyCallRequest,
+) (blockNumber *big.Int, returnData [][]byte, err error) {
+	returnData = make([][]byte, len(calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	var blockNumberMu sync.Mutex
+
+	err = executeBatches(ctx, cfg, calls,
+		func(batch []LegacyCallRequest) ([]byte, *big.Int, error) {
+			data, err := multicallABI.Pack("aggregate", batch)
+			return data, nil, err
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			return cfg.Client.CallContract(ctx, callMsg, nil)
+		},
+		func(start int, raw []byte) error {
+			var response struct {
+				BlockNumber *big.Int
+				ReturnData  [][]byte
+			}
+			if err := multicallABI.UnpackIntoInterface(&response, "aggregate", raw); err != nil {
+				return err
+			}
+
+			blockNumberMu.Lock()
+			blockNumber = response.BlockNumber
+			blockNumberMu.Unlock()
+
+			for j := range response.ReturnData {
+				returnData[start+j] = response.ReturnData[j]
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blockNumber, returnData, nil
+}
+
+// BlockAndAggregate executes calls through the Multicall3 `blockAndAggregate`
+// method, returning the block the calls were executed against alongside the
+// results. This lets callers use multicall as a consistent state snapshot.
+//
+// blockAndAggregate takes plain (target, callData) calls, so each
+// CallRequest's AllowFailure is not sent: every call must succeed, and a
+// single reverting call reverts the whole batch.
+//
+// Note that if calls span more than one batch, each batch is a separate
+// eth_call and is not guaranteed to observe the same block; the returned
+// block number/hash are those of the last batch to complete. Set
+// cfg.BatchSize to at least len(calls) for a true single-block snapshot.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute.
+//
+// Returns:
+//   - blockNumber: The block the calls were executed against
+//   - blockHash: The hash of that block
+//   - results: Packed results and success status of the calls
+//   - error: An error if the multicall fails
+func BlockAndAggregate(
+	cfg *MulticallConfig,
+	calls []CallRequest,
+) (blockNumber *big.Int, blockHash common.Hash, results []CallResult, err error) {
+	results = make([]CallResult, len(calls))
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	var blockMu sync.Mutex
+
+	err = executeBatches(ctx, cfg, calls,
+		func(batch []CallRequest) ([]byte, *big.Int, error) {
+			data, err := multicallABI.Pack("blockAndAggregate", batch)
+			return data, nil, err
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			return cfg.Client.CallContract(ctx, callMsg, nil)
+		},
+		func(start int, raw []byte) error {
+			var response struct {
+				BlockNumber *big.Int
+				BlockHash   [32]byte
+				ReturnData  []CallResult
+			}
+			if err := multicallABI.UnpackIntoInterface(&response, "blockAndAggregate", raw); err != nil {
+				return err
+			}
+
+			blockMu.Lock()
+			blockNumber = response.BlockNumber
+			blockHash = response.BlockHash
+			blockMu.Unlock()
+
+			for j := range response.ReturnData {
+				results[start+j] = response.ReturnData[j]
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, common.Hash{}, nil, err
+	}
+
+	return blockNumber, blockHash, results, nil
+}
@@ -0,0 +1,145 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMulticallRawValue_SumsCallValues(t *testing.T) {
+	var gotValue *big.Int
+	client := &fakeEthCaller{
+		callContract: func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			gotValue = msg.Value
+			results := []CallResult{{Success: true, Data: []byte{}}, {Success: true, Data: []byte{}}}
+			return packOutputs("aggregate3Value", toFakeResults(results))
+		},
+	}
+
+	calls := []CallRequestValue{
+		NewCallRequestValue(common.HexToAddress("0x1"), []byte{0x01}, false, big.NewInt(100)),
+		NewCallRequestValue(common.HexToAddress("0x2"), []byte{0x02}, false, big.NewInt(23)),
+	}
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x9"))
+	results, err := MulticallRawValue(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if gotValue == nil || gotValue.Cmp(big.NewInt(123)) != 0 {
+		t.Fatalf("expected msg.value 123, got %v", gotValue)
+	}
+}
+
+func TestMulticallRawValue_TreatsNilValueAsZero(t *testing.T) {
+	var gotValue *big.Int
+	client := &fakeEthCaller{
+		callContract: func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			gotValue = msg.Value
+			results := []CallResult{{Success: true, Data: []byte{}}}
+			return packOutputs("aggregate3Value", toFakeResults(results))
+		},
+	}
+
+	calls := []CallRequestValue{
+		NewCallRequestValue(common.HexToAddress("0x1"), []byte{0x01}, false, nil),
+	}
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x9"))
+	if _, err := MulticallRawValue(cfg, calls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotValue == nil || gotValue.Sign() != 0 {
+		t.Fatalf("expected msg.value 0, got %v", gotValue)
+	}
+}
+
+func TestAggregate_UnpacksBlockNumberAndReturnData(t *testing.T) {
+	wantBlock := big.NewInt(12345)
+	wantReturnData := [][]byte{{0xaa}, {0xbb}}
+
+	client := &fakeEthCaller{
+		callContract: func(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			return packOutputs("aggregate", wantBlock, wantReturnData)
+		},
+	}
+
+	calls := []LegacyCallRequest{
+		NewLegacyCallRequest(common.HexToAddress("0x1"), []byte{0x01}),
+		NewLegacyCallRequest(common.HexToAddress("0x2"), []byte{0x02}),
+	}
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x9"))
+	blockNumber, returnData, err := Aggregate(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blockNumber.Cmp(wantBlock) != 0 {
+		t.Fatalf("expected block number %v, got %v", wantBlock, blockNumber)
+	}
+	if len(returnData) != 2 || string(returnData[0]) != string(wantReturnData[0]) || string(returnData[1]) != string(wantReturnData[1]) {
+		t.Fatalf("expected return data %v, got %v", wantReturnData, returnData)
+	}
+}
+
+func TestBlockAndAggregate_UnpacksBlockAndResults(t *testing.T) {
+	wantBlock := big.NewInt(777)
+	wantHash := common.HexToHash("0xdeadbeef")
+	wantResults := []CallResult{{Success: true, Data: []byte{0x01}}, {Success: false, Data: []byte{0x02}}}
+
+	client := &fakeEthCaller{
+		callContract: func(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			return packOutputs("blockAndAggregate", wantBlock, wantHash, toFakeResults(wantResults))
+		},
+	}
+
+	calls := []CallRequest{
+		NewCallRequest(common.HexToAddress("0x1"), []byte{0x01}, true),
+		NewCallRequest(common.HexToAddress("0x2"), []byte{0x02}, true),
+	}
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x9"))
+	blockNumber, blockHash, results, err := BlockAndAggregate(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blockNumber.Cmp(wantBlock) != 0 {
+		t.Fatalf("expected block number %v, got %v", wantBlock, blockNumber)
+	}
+	if blockHash != wantHash {
+		t.Fatalf("expected block hash %v, got %v", wantHash, blockHash)
+	}
+	if len(results) != 2 || results[0].Success != true || results[1].Success != false {
+		t.Fatalf("expected results %+v, got %+v", wantResults, results)
+	}
+}
+
+func TestTryAggregate_UnpacksResults(t *testing.T) {
+	wantResults := []CallResult{{Success: true, Data: []byte{0x01, 0x02}}, {Success: true, Data: []byte{0x03}}}
+
+	client := &fakeEthCaller{
+		callContract: func(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			return packOutputs("tryAggregate", toFakeResults(wantResults))
+		},
+	}
+
+	calls := []LegacyCallRequest{
+		NewLegacyCallRequest(common.HexToAddress("0x1"), []byte{0x01}),
+		NewLegacyCallRequest(common.HexToAddress("0x2"), []byte{0x02}),
+	}
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x9"))
+	results, err := TryAggregate(cfg, true, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || string(results[0].Data) != string(wantResults[0].Data) || string(results[1].Data) != string(wantResults[1].Data) {
+		t.Fatalf("expected results %+v, got %+v", wantResults, results)
+	}
+}
@@ -0,0 +1,63 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// PendingBlock can be passed as the blockNumber argument to MulticallRawAt to
+// execute against the pending block instead of a specific historical one.
+var PendingBlock = big.NewInt(rpc.PendingBlockNumber.Int64())
+
+// MulticallRawAt is identical to MulticallRaw, except the calls are executed
+// against a specific block instead of always running at "latest". Pass
+// PendingBlock to run against the pending block.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute.
+//   - blockNumber: The block to execute the calls at. nil means "latest".
+//
+// Returns:
+//   - []CallResult: Packed results and success status of the calls
+//   - error: An error if the multicall fails
+func MulticallRawAt(
+	cfg *MulticallConfig,
+	calls []CallRequest,
+	blockNumber *big.Int,
+) ([]CallResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	return multicallRawAt(ctx, cfg, calls, func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+		return cfg.Client.CallContract(ctx, callMsg, blockNumber)
+	})
+}
+
+// MulticallRawAtHash is identical to MulticallRaw, except the calls are
+// executed against the exact block identified by blockHash, per EIP-1898.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute.
+//   - blockHash: The hash of the block to execute the calls at.
+//
+// Returns:
+//   - []CallResult: Packed results and success status of the calls
+//   - error: An error if the multicall fails
+func MulticallRawAtHash(
+	cfg *MulticallConfig,
+	calls []CallRequest,
+	blockHash common.Hash,
+) ([]CallResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	return multicallRawAt(ctx, cfg, calls, func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+		return cfg.Client.CallContractAtHash(ctx, callMsg, blockHash)
+	})
+}
@@ -0,0 +1,53 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeEthCaller is a network-free EthCaller for unit tests: CallContract is
+// handled by a test-supplied closure instead of a live RPC connection.
+type fakeEthCaller struct {
+	callContract func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+func (f *fakeEthCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return f.callContract(ctx, msg, blockNumber)
+}
+
+func (f *fakeEthCaller) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return f.callContract(ctx, msg, nil)
+}
+
+func (f *fakeEthCaller) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+// packOutputs ABI-encodes args as the outputs of multicallABI's method,
+// mirroring what the real Multicall3 contract would return, so the package's
+// own Unpack calls can be exercised without a live RPC endpoint.
+func packOutputs(method string, args ...interface{}) ([]byte, error) {
+	return multicallABI.Methods[method].Outputs.Pack(args...)
+}
+
+// fakeResult mirrors the Multicall3.Result tuple (success, returnData) field
+// for field, unlike CallResult, so it can be packed by abi.Arguments.Pack:
+// packing a struct into a tuple matches fields by name (via an `abi` tag or
+// the ABI argument's camel-cased name), while unpacking a tuple into a struct
+// matches fields positionally. CallResult relies on the latter, so it has no
+// `abi` tags and can't be used on the packing side.
+type fakeResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+func toFakeResults(results []CallResult) []fakeResult {
+	out := make([]fakeResult, len(results))
+	for i, r := range results {
+		out[i] = fakeResult{Success: r.Success, ReturnData: r.Data}
+	}
+	return out
+}
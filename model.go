@@ -1,15 +1,27 @@
 package multicall
 
 import (
+	"context"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 const defaultBatchSize = 100
 const defaultTimeout = 30 * time.Second
 
+// EthCaller is the subset of *ethclient.Client's API multicall needs to
+// execute calls. *ethclient.Client and *MultiClient both satisfy it, so
+// MulticallConfig.Client can be set to either.
+type EthCaller interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
 // CallRequest is a packed call to be executed with multicall.
 // ABI Definition: Call3
 //
@@ -45,11 +57,18 @@ type CallResult struct {
 //     Default: 100
 //   - Timeout: The timeout for the multicall.
 //     Default: 30 seconds
+//   - Concurrency: The maximum number of batches to have in flight at once.
+//     Default: 1 (batches are executed sequentially)
+//   - Errors: Custom error ABIs to match against when decoding a revert with
+//     MulticallConfig.DecodeRevert, so they can be auto-decoded by name
+//     instead of being returned as a raw CustomError.
 type MulticallConfig struct {
-	Client           *ethclient.Client
+	Client           EthCaller
 	MulticallAddress common.Address
 	BatchSize        int
 	Timeout          time.Duration
+	Concurrency      int
+	Errors           []abi.Error
 }
 
 // Create a new reusable multicall config
@@ -62,7 +81,7 @@ type MulticallConfig struct {
 //     Generally it's cheaper and faster to pack as many calls as possible in a single multicall.
 //     Default: 100
 func NewMulticallConfig(
-	client *ethclient.Client,
+	client EthCaller,
 	multicallAddress common.Address,
 	batchSize int,
 	timeout time.Duration,
@@ -81,7 +100,7 @@ func NewMulticallConfig(
 //   - client: The Ethereum client to use
 //   - multicallAddress: The address of the multicall contract for the chain.
 //     You can find the deployment address for your chain at https://www.multicall3.com/deployments
-func NewDefaultMulticallConfig(client *ethclient.Client, multicallAddress common.Address) *MulticallConfig {
+func NewDefaultMulticallConfig(client EthCaller, multicallAddress common.Address) *MulticallConfig {
 	return NewMulticallConfig(client, multicallAddress, defaultBatchSize, defaultTimeout)
 }
 
@@ -92,3 +111,48 @@ func NewCallRequest(
 ) CallRequest {
 	return CallRequest{target, allowFailure, packedCallData}
 }
+
+// CallRequestValue is a packed call to be executed with MulticallRawValue,
+// carrying an explicit msg.value for the target call.
+// ABI Definition: Call3Value
+//
+// Parameters:
+//   - Target: The address of the contract to call
+//   - AllowFailure: Whether the call is allowed to fail
+//   - Value: The value (in wei) to send with the call
+//   - CallData: packed calldata to the target contract with abi.Pack
+type CallRequestValue struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+func NewCallRequestValue(
+	target common.Address,
+	packedCallData []byte,
+	allowFailure bool,
+	value *big.Int,
+) CallRequestValue {
+	return CallRequestValue{target, allowFailure, value, packedCallData}
+}
+
+// LegacyCallRequest is a packed call compatible with the Multicall v1
+// `aggregate` method and the v2/v3 `tryAggregate` method, neither of which
+// support a per-call AllowFailure flag.
+// ABI Definition: Call
+//
+// Parameters:
+//   - Target: The address of the contract to call
+//   - CallData: packed calldata to the target contract with abi.Pack
+type LegacyCallRequest struct {
+	Target   common.Address
+	CallData []byte
+}
+
+func NewLegacyCallRequest(
+	target common.Address,
+	packedCallData []byte,
+) LegacyCallRequest {
+	return LegacyCallRequest{target, packedCallData}
+}
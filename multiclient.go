@@ -0,0 +1,173 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// RetryPolicy configures how MultiClient retries a failed call against its
+// other endpoints.
+//
+// Parameters:
+//   - MaxAttempts: The maximum number of attempts before giving up. Attempts
+//     cycle through the endpoints in round-robin order, so an endpoint is
+//     tried again if MaxAttempts exceeds len(endpoints).
+//     Default: len(endpoints)
+//   - InitialBackoff: The delay before the first retry.
+//   - BackoffMultiplier: The factor InitialBackoff is multiplied by after each retry.
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	BackoffMultiplier float64
+}
+
+// DefaultRetryPolicy is used by NewDefaultMultiClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       3,
+	InitialBackoff:    250 * time.Millisecond,
+	BackoffMultiplier: 2,
+}
+
+// MultiClient wraps multiple Ethereum endpoints behind a single EthCaller, so
+// it can be used as MulticallConfig.Client directly. Calls are dispatched
+// round-robin across the endpoints; a call that fails with a transient error
+// (timeout, HTTP 429, the `-32005` rate-limit RPC error, connection reset) is
+// retried against the next endpoint with exponential backoff instead of
+// failing outright. A call that fails for any other reason, e.g. a
+// deterministic revert, is returned immediately without retrying, since
+// retrying it against another endpoint would just waste a round trip.
+type MultiClient struct {
+	endpoints []*ethclient.Client
+	policy    RetryPolicy
+	next      uint64 // atomic round-robin cursor
+}
+
+// NewMultiClient creates a MultiClient over endpoints using policy. A
+// MaxAttempts of 0 in policy defaults to len(endpoints).
+func NewMultiClient(endpoints []*ethclient.Client, policy RetryPolicy) (*MultiClient, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("MultiClient requires at least one endpoint")
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = len(endpoints)
+	}
+	return &MultiClient{endpoints: endpoints, policy: policy}, nil
+}
+
+// NewDefaultMultiClient creates a MultiClient over endpoints using DefaultRetryPolicy.
+func NewDefaultMultiClient(endpoints []*ethclient.Client) (*MultiClient, error) {
+	return NewMultiClient(endpoints, DefaultRetryPolicy)
+}
+
+// CallContract implements EthCaller.
+func (m *MultiClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return callWithFailover(ctx, m, func(client *ethclient.Client) ([]byte, error) {
+		return client.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// CallContractAtHash implements EthCaller.
+func (m *MultiClient) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	return callWithFailover(ctx, m, func(client *ethclient.Client) ([]byte, error) {
+		return client.CallContractAtHash(ctx, msg, blockHash)
+	})
+}
+
+// ChainID implements EthCaller.
+func (m *MultiClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return callWithFailover(ctx, m, func(client *ethclient.Client) (*big.Int, error) {
+		return client.ChainID(ctx)
+	})
+}
+
+// callWithFailover runs call against m's endpoints in round-robin order,
+// starting from a different endpoint on each invocation, retrying transient
+// errors with exponential backoff up to m.policy.MaxAttempts times.
+func callWithFailover[T any](ctx context.Context, m *MultiClient, call func(client *ethclient.Client) (T, error)) (T, error) {
+	return retryAcrossEndpoints(ctx, len(m.endpoints), &m.next, m.policy, func(endpointIndex int) (T, error) {
+		return call(m.endpoints[endpointIndex])
+	})
+}
+
+// retryAcrossEndpoints implements the round-robin-with-backoff retry policy
+// shared by every MultiClient method. It is independent of the endpoint
+// type (attempt is given an endpoint index to dispatch on) so the retry and
+// failover behavior can be unit tested without a live RPC client.
+//
+// next is advanced atomically so concurrent calls fan out across endpoints
+// instead of always starting from the same one.
+func retryAcrossEndpoints[T any](
+	ctx context.Context,
+	n int,
+	next *uint64,
+	policy RetryPolicy,
+	attempt func(endpointIndex int) (T, error),
+) (T, error) {
+	var zero T
+
+	start := int(atomic.AddUint64(next, 1)-1) % n
+	backoff := policy.InitialBackoff
+
+	var lastErr error
+	for i := 0; i < policy.MaxAttempts; i++ {
+		result, err := attempt((start + i) % n)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if !isTransientError(err) {
+			return zero, err
+		}
+		if i == policy.MaxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = time.Duration(float64(backoff) * policy.BackoffMultiplier)
+	}
+
+	return zero, fmt.Errorf("all endpoints failed after %d attempts, last error: %w", policy.MaxAttempts, lastErr)
+}
+
+// isTransientError reports whether err is likely caused by rate limiting or a
+// dropped connection, rather than the request itself being invalid. Only
+// transient errors are worth retrying against a different endpoint.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, needle := range []string{
+		"context deadline exceeded",
+		"429",
+		"-32005",
+		"rate limit",
+		"too many requests",
+		"connection reset",
+		"econnreset",
+		"eof",
+	} {
+		if strings.Contains(msg, needle) {
+			return true
+		}
+	}
+	return false
+}
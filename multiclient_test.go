@@ -0,0 +1,131 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsTransientError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"wrapped deadline exceeded", fmt.Errorf("rpc call failed: %w", context.DeadlineExceeded), true},
+		{"http 429", errors.New("HTTP 429 Too Many Requests"), true},
+		{"rate limit message", errors.New("rate limit exceeded, try again later"), true},
+		{"-32005 rpc error", errors.New("-32005: request rate exceeded"), true},
+		{"connection reset", errors.New("read: connection reset by peer"), true},
+		{"eof", errors.New("unexpected EOF"), true},
+		{"revert", errors.New("execution reverted: insufficient balance"), false},
+		{"unrelated error", errors.New("invalid argument"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isTransientError(c.err); got != c.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+// transientErr lets tests manufacture an error that isTransientError
+// recognizes without depending on a real RPC failure.
+type transientErr struct{ msg string }
+
+func (e transientErr) Error() string { return e.msg }
+
+func newTransientErr() error { return transientErr{"connection reset by peer"} }
+
+func TestRetryAcrossEndpoints_RetriesTransientThenSucceeds(t *testing.T) {
+	var attempts []int
+	var next uint64
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, BackoffMultiplier: 2}
+
+	result, err := retryAcrossEndpoints(context.Background(), 3, &next, policy, func(endpointIndex int) (string, error) {
+		attempts = append(attempts, endpointIndex)
+		if len(attempts) < 3 {
+			return "", newTransientErr()
+		}
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "ok" {
+		t.Fatalf("expected result %q, got %q", "ok", result)
+	}
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d: %v", len(attempts), attempts)
+	}
+	// Each retry should move to the next endpoint in round-robin order.
+	for i := 1; i < len(attempts); i++ {
+		if attempts[i] == attempts[i-1] {
+			t.Fatalf("expected a different endpoint on retry, got %v", attempts)
+		}
+	}
+}
+
+func TestRetryAcrossEndpoints_GivesUpAfterMaxAttempts(t *testing.T) {
+	var next uint64
+	calls := 0
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, BackoffMultiplier: 2}
+
+	_, err := retryAcrossEndpoints(context.Background(), 2, &next, policy, func(endpointIndex int) (string, error) {
+		calls++
+		return "", newTransientErr()
+	})
+	if err == nil {
+		t.Fatal("expected an error after exhausting all attempts")
+	}
+	if calls != policy.MaxAttempts {
+		t.Fatalf("expected %d attempts, got %d", policy.MaxAttempts, calls)
+	}
+}
+
+func TestRetryAcrossEndpoints_DoesNotRetryNonTransientError(t *testing.T) {
+	var next uint64
+	calls := 0
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, BackoffMultiplier: 2}
+	revertErr := errors.New("execution reverted: insufficient balance")
+
+	_, err := retryAcrossEndpoints(context.Background(), 2, &next, policy, func(endpointIndex int) (string, error) {
+		calls++
+		return "", revertErr
+	})
+	if !errors.Is(err, revertErr) {
+		t.Fatalf("expected the original revert error to be returned unwrapped, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 attempt for a non-transient error, got %d", calls)
+	}
+}
+
+func TestRetryAcrossEndpoints_AbortsOnContextCancel(t *testing.T) {
+	var next uint64
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, BackoffMultiplier: 2}
+	calls := 0
+
+	_, err := retryAcrossEndpoints(ctx, 2, &next, policy, func(endpointIndex int) (string, error) {
+		calls++
+		return "", newTransientErr()
+	})
+	if err == nil {
+		t.Fatal("expected an error when the context is already canceled")
+	}
+	if calls != 1 {
+		t.Fatalf("expected the first attempt to run before the backoff wait is aborted, got %d calls", calls)
+	}
+}
@@ -0,0 +1,170 @@
+package multicall
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// errorSelector and panicSelector are the function selectors Solidity uses
+// for its two built-in revert shapes: Error(string) and Panic(uint256).
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// panicReasons maps the documented Solidity panic codes to a human-readable
+// description, as passed to Panic(uint256) by the compiler's generated
+// checks. See https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicReasons = map[byte]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic operation overflowed outside of an unchecked block",
+	0x12: "division or modulo by zero",
+	0x21: "tried to convert to an invalid type",
+	0x22: "tried to access a storage byte array that is incorrectly encoded",
+	0x31: "pop() was called on an empty array",
+	0x32: "array out-of-bounds or negative index access",
+	0x41: "out of memory or array too large",
+	0x51: "called a zero-initialized variable of internal function type",
+}
+
+// CustomError is a decoded Solidity custom error that DecodeRevert could not
+// resolve to a human-readable reason, because it is neither a standard
+// Error(string) nor a Panic(uint256). Match Selector against your own ABI's
+// errors to decode Data, or register the ABI on MulticallConfig.Errors and
+// use MulticallConfig.DecodeRevert instead.
+//
+// Parameters:
+//   - Selector: The 4-byte custom error selector
+//   - Data: The ABI-encoded error arguments, following Selector
+type CustomError struct {
+	Selector [4]byte
+	Data     []byte
+}
+
+// DecodeRevert decodes the revert data returned by a failed Call3 result
+// (CallResult.Data when Success is false) into a human-readable reason.
+// It understands the two revert shapes the Solidity compiler emits
+// automatically, Error(string) and Panic(uint256), and otherwise returns the
+// raw selector and arguments as a CustomError for the caller to match against
+// their own ABI.
+//
+// Parameters:
+//   - data: The revert data to decode, e.g. CallResult.Data
+//
+// Returns:
+//   - reason: A human-readable revert reason, set only for Error(string) and Panic(uint256)
+//   - custom: The decoded selector and raw args, set only for unrecognized custom errors
+//   - err: An error if data is malformed (too short, or a mismatched Error/Panic encoding)
+func DecodeRevert(data []byte) (reason string, custom *CustomError, err error) {
+	if len(data) < 4 {
+		return "", nil, fmt.Errorf("revert data too short to contain a selector: %d bytes", len(data))
+	}
+
+	selector, args := data[:4], data[4:]
+
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		stringType, err := abi.NewType("string", "", nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build string type: %w", err)
+		}
+		unpacked, err := (abi.Arguments{{Type: stringType}}).Unpack(args)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to unpack Error(string) revert: %w", err)
+		}
+		return unpacked[0].(string), nil, nil
+
+	case bytes.Equal(selector, panicSelector):
+		uint256Type, err := abi.NewType("uint256", "", nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build uint256 type: %w", err)
+		}
+		unpacked, err := (abi.Arguments{{Type: uint256Type}}).Unpack(args)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to unpack Panic(uint256) revert: %w", err)
+		}
+		code := unpacked[0].(*big.Int)
+		if reason, ok := panicReasons[byte(code.Uint64())]; ok {
+			return fmt.Sprintf("panic: %s (0x%02x)", reason, code), nil, nil
+		}
+		return fmt.Sprintf("panic: unknown panic code 0x%02x", code), nil, nil
+
+	default:
+		var sel [4]byte
+		copy(sel[:], selector)
+		return "", &CustomError{Selector: sel, Data: args}, nil
+	}
+}
+
+// DecodeRevert is identical to the package-level DecodeRevert, except custom
+// errors are additionally matched against cfg.Errors by selector, so they can
+// be auto-decoded by name instead of being returned as a raw CustomError.
+//
+// Parameters:
+//   - data: The revert data to decode, e.g. CallResult.Data
+//
+// Returns:
+//   - reason: A human-readable revert reason
+//   - custom: The decoded selector and raw args, set only if the error did not match Error/Panic/cfg.Errors
+//   - err: An error if data is malformed
+func (cfg *MulticallConfig) DecodeRevert(data []byte) (reason string, custom *CustomError, err error) {
+	reason, custom, err = DecodeRevert(data)
+	if err != nil || custom == nil {
+		return reason, custom, err
+	}
+
+	for _, customErr := range cfg.Errors {
+		selector := crypto.Keccak256([]byte(customErr.Sig))[:4]
+		if !bytes.Equal(selector, custom.Selector[:]) {
+			continue
+		}
+		args, err := customErr.Inputs.Unpack(custom.Data)
+		if err != nil {
+			return "", custom, fmt.Errorf("failed to unpack custom error %s: %w", customErr.Name, err)
+		}
+		return fmt.Sprintf("%s%v", customErr.Name, args), nil, nil
+	}
+
+	return reason, custom, nil
+}
+
+// RevertReason decodes Data into a human-readable revert reason. It is a
+// convenience wrapper for the common case where the caller just wants a
+// message to log or surface to a user; use DecodeRevert/MulticallConfig.DecodeRevert
+// directly to distinguish a CustomError from a decode failure.
+//
+// Pass the MulticallConfig the call was made with to auto-decode custom
+// errors registered in its Errors field by name; pass nil to only recognize
+// the standard Error(string)/Panic(uint256) shapes.
+//
+// RevertReason is only meaningful when Success is false; it returns an empty
+// string otherwise.
+func (r CallResult) RevertReason(cfg *MulticallConfig) string {
+	if r.Success {
+		return ""
+	}
+
+	var (
+		reason string
+		custom *CustomError
+		err    error
+	)
+	if cfg != nil {
+		reason, custom, err = cfg.DecodeRevert(r.Data)
+	} else {
+		reason, custom, err = DecodeRevert(r.Data)
+	}
+
+	switch {
+	case err != nil:
+		return err.Error()
+	case custom != nil:
+		return fmt.Sprintf("custom error %#x", custom.Selector)
+	default:
+		return reason
+	}
+}
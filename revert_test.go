@@ -0,0 +1,145 @@
+package multicall
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func mustPackError(t *testing.T, reason string) []byte {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		t.Fatalf("failed to build string type: %v", err)
+	}
+	packed, err := (abi.Arguments{{Type: stringType}}).Pack(reason)
+	if err != nil {
+		t.Fatalf("failed to pack Error(string) args: %v", err)
+	}
+	return append(append([]byte{}, errorSelector...), packed...)
+}
+
+func mustPackPanic(t *testing.T, code int64) []byte {
+	t.Helper()
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		t.Fatalf("failed to build uint256 type: %v", err)
+	}
+	packed, err := (abi.Arguments{{Type: uint256Type}}).Pack(big.NewInt(code))
+	if err != nil {
+		t.Fatalf("failed to pack Panic(uint256) args: %v", err)
+	}
+	return append(append([]byte{}, panicSelector...), packed...)
+}
+
+func TestDecodeRevert_Error(t *testing.T) {
+	data := mustPackError(t, "insufficient balance")
+
+	reason, custom, err := DecodeRevert(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if custom != nil {
+		t.Fatalf("expected no custom error, got %+v", custom)
+	}
+	if reason != "insufficient balance" {
+		t.Fatalf("expected reason %q, got %q", "insufficient balance", reason)
+	}
+}
+
+func TestDecodeRevert_Panic(t *testing.T) {
+	for code, want := range panicReasons {
+		data := mustPackPanic(t, int64(code))
+
+		reason, custom, err := DecodeRevert(data)
+		if err != nil {
+			t.Fatalf("code 0x%02x: unexpected error: %v", code, err)
+		}
+		if custom != nil {
+			t.Fatalf("code 0x%02x: expected no custom error, got %+v", code, custom)
+		}
+		if !strings.Contains(reason, want) {
+			t.Fatalf("code 0x%02x: expected reason to contain %q, got %q", code, want, reason)
+		}
+	}
+}
+
+func TestDecodeRevert_PanicUnknownCode(t *testing.T) {
+	data := mustPackPanic(t, 0x99)
+
+	reason, custom, err := DecodeRevert(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if custom != nil {
+		t.Fatalf("expected no custom error, got %+v", custom)
+	}
+	if !strings.Contains(reason, "unknown panic code") {
+		t.Fatalf("expected reason to mention an unknown panic code, got %q", reason)
+	}
+}
+
+func TestDecodeRevert_CustomError(t *testing.T) {
+	selector := []byte{0xde, 0xad, 0xbe, 0xef}
+	args := []byte{0x01, 0x02, 0x03}
+	data := append(append([]byte{}, selector...), args...)
+
+	reason, custom, err := DecodeRevert(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reason != "" {
+		t.Fatalf("expected no reason for a custom error, got %q", reason)
+	}
+	if custom == nil {
+		t.Fatal("expected a CustomError, got nil")
+	}
+	if custom.Selector != [4]byte{0xde, 0xad, 0xbe, 0xef} {
+		t.Fatalf("expected selector %x, got %x", selector, custom.Selector)
+	}
+	if string(custom.Data) != string(args) {
+		t.Fatalf("expected args %x, got %x", args, custom.Data)
+	}
+}
+
+func TestDecodeRevert_TooShort(t *testing.T) {
+	if _, _, err := DecodeRevert([]byte{0x01, 0x02}); err == nil {
+		t.Fatal("expected an error for data shorter than a selector")
+	}
+}
+
+func TestMulticallConfig_DecodeRevert_CustomErrorByName(t *testing.T) {
+	errABIJSON := `[{"type":"error","name":"InsufficientAllowance","inputs":[{"name":"needed","type":"uint256"}]}]`
+	parsed, err := abi.JSON(strings.NewReader(errABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse ABI: %v", err)
+	}
+
+	customErr := parsed.Errors["InsufficientAllowance"]
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		t.Fatalf("failed to build uint256 type: %v", err)
+	}
+	args, err := (abi.Arguments{{Type: uint256Type}}).Pack(big.NewInt(42))
+	if err != nil {
+		t.Fatalf("failed to pack custom error args: %v", err)
+	}
+
+	selector := crypto.Keccak256([]byte(customErr.Sig))[:4]
+	data := append(append([]byte{}, selector...), args...)
+
+	cfg := &MulticallConfig{Errors: []abi.Error{customErr}}
+	reason, custom, err := cfg.DecodeRevert(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if custom != nil {
+		t.Fatalf("expected custom error to be resolved by name, got raw %+v", custom)
+	}
+	if !strings.Contains(reason, "InsufficientAllowance") {
+		t.Fatalf("expected reason to mention the error name, got %q", reason)
+	}
+}
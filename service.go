@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum"
@@ -45,44 +46,169 @@ func MulticallRaw(
 	cfg *MulticallConfig,
 	calls []CallRequest,
 ) ([]CallResult, error) {
-	results := make([]CallResult, len(calls))
-
-	// Create a context with a deadline
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel() // Ensure resources are cleaned up
 
-	for i := 0; i < len(calls); i += cfg.BatchSize {
-		batch := calls[i:min(i+cfg.BatchSize, len(calls))]
-		callData, err := multicallABI.Pack("aggregate3", batch)
-		if err != nil {
-			return nil, fmt.Errorf("failed to pack multicall %d: %w", i, err)
-		}
+	return multicallRawAt(ctx, cfg, calls, func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+		return cfg.Client.CallContract(ctx, callMsg, nil)
+	})
+}
 
-		callMsg := ethereum.CallMsg{
-			To:   &cfg.MulticallAddress,
-			Data: callData,
-		}
+// multicallRawAt runs the aggregate3 batch loop shared by MulticallRaw,
+// MulticallRawAt and MulticallRawAtHash, deferring the actual eth_call to
+// execute so each variant can pin a different block.
+func multicallRawAt(
+	ctx context.Context,
+	cfg *MulticallConfig,
+	calls []CallRequest,
+	execute func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error),
+) ([]CallResult, error) {
+	results := make([]CallResult, len(calls))
 
-		// Execute the multicall with the context
-		result, err := cfg.Client.CallContract(ctx, callMsg, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to execute multicall %d: %w", i, err)
-		}
+	err := executeBatches(ctx, cfg, calls,
+		func(batch []CallRequest) ([]byte, *big.Int, error) {
+			data, err := multicallABI.Pack("aggregate3", batch)
+			return data, nil, err
+		},
+		execute,
+		func(start int, raw []byte) error {
+			var response []CallResult
+			if err := multicallABI.UnpackIntoInterface(&response, "aggregate3", raw); err != nil {
+				return err
+			}
+			for j := range response {
+				results[start+j] = response[j]
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		// Unpack the result
-		var response []CallResult
+	return results, nil
+}
 
-		// Unpacking the response from the contract
-		err = multicallABI.UnpackIntoInterface(&response, "aggregate3", result)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unpack multicall %d: %w", i, err)
-		}
+// executeBatches is the batch-dispatch engine shared by every aggregate mode
+// (aggregate3, aggregate3Value, tryAggregate, aggregate, blockAndAggregate).
+// It slices calls into batches of cfg.BatchSize (guarded to at least 1, same
+// as cfg.Concurrency below), packs and executes each batch, and hands its
+// raw return data to onBatchResult to unpack and store.
+//
+// Batches are dispatched through a worker pool bounded by cfg.Concurrency
+// (default 1, i.e. sequential, for back-compat). On the first error, ctx is
+// canceled so in-flight and queued batches abort promptly. Because batches
+// may run concurrently, onBatchResult must be safe for concurrent use
+// whenever it touches state shared across batches (results keyed by a
+// disjoint index range per batch need no extra synchronization; a single
+// shared value like a block number does).
+//
+// Parameters:
+//   - ctx: The context to execute batches under; canceled internally on the first error
+//   - cfg: The multicall config to use
+//   - calls: The calls to execute, sliced into batches of cfg.BatchSize
+//   - packCallData: Packs one batch into calldata, plus an optional msg.value (nil if none)
+//   - execute: Performs the actual eth_call for a packed batch, e.g. at a pinned block
+//   - onBatchResult: Unpacks and stores a batch's raw return data, given the batch's start index
+func executeBatches[C any](
+	ctx context.Context,
+	cfg *MulticallConfig,
+	calls []C,
+	packCallData func(batch []C) (callData []byte, value *big.Int, err error),
+	execute func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error),
+	onBatchResult func(start int, raw []byte) error,
+) error {
+	batchSize := cfg.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
 
-		// Store the results as CallResultRaw
-		for j := range response {
-			results[i+j] = response[j]
+	concurrency := cfg.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batchStarts := make([]int, 0, (len(calls)+batchSize-1)/batchSize)
+	for i := 0; i < len(calls); i += batchSize {
+		batchStarts = append(batchStarts, i)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, concurrency)
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for _, start := range batchStarts {
+		start := start
+		batch := calls[start:min(start+batchSize, len(calls))]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return firstErr
 		}
+		// A failing batch cancels ctx before freeing its slot, so a slot
+		// freed by a failure must not start a queued batch.
+		if ctx.Err() != nil {
+			<-sem
+			wg.Wait()
+			return firstErr
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := execBatch(ctx, cfg, packCallData, execute, onBatchResult, start, batch); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel() // abort in-flight and queued batches on the first error
+				})
+			}
+		}()
 	}
 
-	return results, nil
+	wg.Wait()
+	return firstErr
+}
+
+// execBatch packs, executes and unpacks a single batch, delegating the
+// method-specific packing and unpacking to packCallData/onBatchResult.
+func execBatch[C any](
+	ctx context.Context,
+	cfg *MulticallConfig,
+	packCallData func(batch []C) (callData []byte, value *big.Int, err error),
+	execute func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error),
+	onBatchResult func(start int, raw []byte) error,
+	start int,
+	batch []C,
+) error {
+	callData, value, err := packCallData(batch)
+	if err != nil {
+		return fmt.Errorf("failed to pack multicall %d: %w", start, err)
+	}
+
+	callMsg := ethereum.CallMsg{
+		To:    &cfg.MulticallAddress,
+		Data:  callData,
+		Value: value,
+	}
+
+	result, err := execute(ctx, callMsg)
+	if err != nil {
+		return fmt.Errorf("failed to execute multicall %d: %w", start, err)
+	}
+
+	if err := onBatchResult(start, result); err != nil {
+		return fmt.Errorf("failed to unpack multicall %d: %w", start, err)
+	}
+
+	return nil
 }
@@ -1,10 +1,16 @@
 package multicall
 
 import (
+	"context"
+	"fmt"
+	"math/big"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -151,3 +157,143 @@ func TestMulticallRaw_AllowFailure(t *testing.T) {
 		t.Errorf("Expected empty return data for failed call, got %x", results[1].Data)
 	}
 }
+
+// orderedFakeClient answers aggregate3 calls by echoing back each call's
+// CallData as its result, so tests can assert that batched/concurrent
+// dispatch still produces results in the original request order, without
+// depending on a live RPC endpoint.
+func orderedFakeClient(t *testing.T, batchCalls *int32) *fakeEthCaller {
+	t.Helper()
+	return &fakeEthCaller{
+		callContract: func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			atomic.AddInt32(batchCalls, 1)
+
+			args, err := multicallABI.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+			if err != nil {
+				t.Fatalf("failed to unpack aggregate3 calldata: %v", err)
+			}
+			var calls []CallRequest
+			if err := multicallABI.Methods["aggregate3"].Inputs.Copy(&calls, args); err != nil {
+				t.Fatalf("failed to copy aggregate3 calldata into []CallRequest: %v", err)
+			}
+
+			results := make([]CallResult, len(calls))
+			for i, call := range calls {
+				results[i] = CallResult{Success: true, Data: call.CallData}
+			}
+			return packOutputs("aggregate3", toFakeResults(results))
+		},
+	}
+}
+
+func TestMulticallRaw_PreservesOrderAcrossConcurrentBatches(t *testing.T) {
+	const numCalls = 25
+	const batchSize = 4
+
+	calls := make([]CallRequest, numCalls)
+	for i := range calls {
+		// Each call's data encodes its own index, so the fake client can echo
+		// it back and the test can verify it lands at the matching result index.
+		calls[i] = NewCallRequest(common.HexToAddress("0x1"), []byte{byte(i)}, false)
+	}
+
+	var batchCalls int32
+	client := orderedFakeClient(t, &batchCalls)
+	cfg := NewMulticallConfig(client, common.HexToAddress("0x9"), batchSize, 30*time.Second)
+	cfg.Concurrency = 4
+
+	results, err := MulticallRaw(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != numCalls {
+		t.Fatalf("expected %d results, got %d", numCalls, len(results))
+	}
+	for i, result := range results {
+		if len(result.Data) != 1 || result.Data[0] != byte(i) {
+			t.Fatalf("result %d out of order: got data %v", i, result.Data)
+		}
+	}
+
+	wantBatches := int32((numCalls + batchSize - 1) / batchSize)
+	if batchCalls != wantBatches {
+		t.Fatalf("expected %d batches, got %d", wantBatches, batchCalls)
+	}
+}
+
+func TestMulticallRaw_BatchSizeZeroDoesNotPanic(t *testing.T) {
+	var batchCalls int32
+	client := orderedFakeClient(t, &batchCalls)
+
+	cfg := NewMulticallConfig(client, common.HexToAddress("0x9"), 0, 30*time.Second)
+	calls := []CallRequest{
+		NewCallRequest(common.HexToAddress("0x1"), []byte{0x00}, false),
+		NewCallRequest(common.HexToAddress("0x2"), []byte{0x01}, false),
+	}
+
+	results, err := MulticallRaw(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	// BatchSize<=0 must fall back to a batch size of 1: one call per batch.
+	if batchCalls != 2 {
+		t.Fatalf("expected 2 batches with BatchSize 0, got %d", batchCalls)
+	}
+}
+
+func TestExecuteBatches_AbortsRemainingBatchesOnFirstError(t *testing.T) {
+	var executed int32
+	failAt := int32(2)
+
+	err := executeBatches(context.Background(), &MulticallConfig{BatchSize: 1, Concurrency: 1}, make([]CallRequest, 5),
+		func(batch []CallRequest) ([]byte, *big.Int, error) {
+			return []byte{}, nil, nil
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			n := atomic.AddInt32(&executed, 1)
+			if n == failAt {
+				return nil, fmt.Errorf("simulated failure")
+			}
+			return []byte{}, nil
+		},
+		func(start int, raw []byte) error { return nil },
+	)
+	if err == nil {
+		t.Fatal("expected an error from the failing batch")
+	}
+	if executed > failAt {
+		t.Fatalf("expected execution to stop shortly after the failing batch, got %d executions", executed)
+	}
+}
+
+func TestExecuteBatches_ConcurrencyGuardDefaultsToOne(t *testing.T) {
+	var inFlight int32
+	var maxInFlight int32
+	var mu sync.Mutex
+
+	err := executeBatches(context.Background(), &MulticallConfig{BatchSize: 1, Concurrency: 0}, make([]CallRequest, 4),
+		func(batch []CallRequest) ([]byte, *big.Int, error) {
+			return []byte{}, nil, nil
+		},
+		func(ctx context.Context, callMsg ethereum.CallMsg) ([]byte, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			mu.Lock()
+			if n > maxInFlight {
+				maxInFlight = n
+			}
+			mu.Unlock()
+			atomic.AddInt32(&inFlight, -1)
+			return []byte{}, nil
+		},
+		func(start int, raw []byte) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxInFlight > 1 {
+		t.Fatalf("expected Concurrency<1 to default to sequential execution, saw %d batches in flight at once", maxInFlight)
+	}
+}
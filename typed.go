@@ -0,0 +1,144 @@
+package multicall
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TypedCall is a single call to be executed with MulticallTyped. Unlike
+// CallRequest, the caller does not pack or unpack anything by hand: the ABI,
+// method and args are used to pack the call, and the same ABI/method is used
+// to unpack the result into T.
+//
+// Parameters:
+//   - Target: The address of the contract to call
+//   - ABI: The ABI describing Method
+//   - Method: The name of the method to call, as defined in ABI
+//   - Args: The arguments to pass to Method
+//   - AllowFailure: Whether the call is allowed to fail
+type TypedCall[T any] struct {
+	Target       common.Address
+	ABI          abi.ABI
+	Method       string
+	Args         []interface{}
+	AllowFailure bool
+}
+
+// TypedResult is the decoded result of a TypedCall.
+//
+// Parameters:
+//   - Success: Whether the call was successful
+//   - Value: The decoded return value. Zero value of T if Success is false.
+//   - Err: Set if the call succeeded but T could not be decoded from the
+//     returned data. A packing error is returned directly from MulticallTyped
+//     instead, since it means the call was never sent.
+type TypedResult[T any] struct {
+	Success bool
+	Value   T
+	Err     error
+}
+
+// PreparedCall is a TypedCall that has already been packed and carries its
+// own unpacker. Unlike TypedCall[T], PreparedCall has no type parameter, so
+// calls targeting different output types can be mixed in a single
+// MulticallPrepared batch.
+type PreparedCall struct {
+	request CallRequest
+	unpack  func([]byte) (interface{}, error)
+}
+
+// NewTypedCall packs call into a PreparedCall, so it can be combined with
+// TypedCall[U] calls of other output types in a single MulticallPrepared
+// batch. Most callers calling a single output type should use MulticallTyped
+// instead.
+func NewTypedCall[T any](call TypedCall[T]) (PreparedCall, error) {
+	callData, err := call.ABI.Pack(call.Method, call.Args...)
+	if err != nil {
+		return PreparedCall{}, fmt.Errorf("failed to pack call to %s.%s: %w", call.Target, call.Method, err)
+	}
+
+	unpack := func(data []byte) (interface{}, error) {
+		var out T
+		if err := call.ABI.UnpackIntoInterface(&out, call.Method, data); err != nil {
+			return out, fmt.Errorf("failed to unpack result of %s.%s: %w", call.Target, call.Method, err)
+		}
+		return out, nil
+	}
+
+	return PreparedCall{
+		request: NewCallRequest(call.Target, callData, call.AllowFailure),
+		unpack:  unpack,
+	}, nil
+}
+
+// MulticallTyped packs calls, executes them with MulticallRaw, and decodes
+// each result into T. All calls in the batch share the same output type; use
+// MulticallPrepared to mix calls with heterogeneous output types.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The typed calls to execute.
+//
+// Returns:
+//   - []TypedResult[T]: Decoded results and success status of the calls
+//   - error: An error if packing or executing the multicall fails. Per-call
+//     decode failures are instead surfaced on TypedResult.Err.
+func MulticallTyped[T any](cfg *MulticallConfig, calls []TypedCall[T]) ([]TypedResult[T], error) {
+	prepared := make([]PreparedCall, len(calls))
+	for i, call := range calls {
+		p, err := NewTypedCall(call)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare typed call %d: %w", i, err)
+		}
+		prepared[i] = p
+	}
+
+	anyResults, err := MulticallPrepared(cfg, prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]TypedResult[T], len(anyResults))
+	for i, r := range anyResults {
+		result := TypedResult[T]{Success: r.Success, Err: r.Err}
+		if r.Success && r.Err == nil {
+			result.Value, _ = r.Value.(T)
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// MulticallPrepared executes a batch of PreparedCall, which may target
+// different output types, and decodes each result with its own unpacker.
+//
+// Parameters:
+//   - cfg: The multicall config to use
+//   - calls: The prepared calls to execute.
+//
+// Returns:
+//   - []TypedResult[any]: Decoded results and success status of the calls
+//   - error: An error if executing the multicall fails.
+func MulticallPrepared(cfg *MulticallConfig, calls []PreparedCall) ([]TypedResult[any], error) {
+	requests := make([]CallRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.request
+	}
+
+	raw, err := MulticallRaw(cfg, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]TypedResult[any], len(raw))
+	for i, r := range raw {
+		result := TypedResult[any]{Success: r.Success}
+		if r.Success {
+			result.Value, result.Err = calls[i].unpack(r.Data)
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,222 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testTypedABIJSON = `[
+	{"type":"function","name":"getNumber","inputs":[{"name":"x","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"getName","inputs":[],"outputs":[{"name":"","type":"string"}],"stateMutability":"view"}
+]`
+
+// aggregate3Fake builds a fakeEthCaller that behaves like a Multicall3
+// deployment executing aggregate3: it decodes the Call3 array from the
+// calldata, dispatches each call to handle, and packs the responses back
+// through the real aggregate3 ABI encoding.
+func aggregate3Fake(t *testing.T, handle func(call CallRequest) (success bool, data []byte)) *fakeEthCaller {
+	t.Helper()
+	return &fakeEthCaller{
+		callContract: func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			args, err := multicallABI.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+			if err != nil {
+				t.Fatalf("failed to unpack aggregate3 calldata: %v", err)
+			}
+			var calls []CallRequest
+			if err := multicallABI.Methods["aggregate3"].Inputs.Copy(&calls, args); err != nil {
+				t.Fatalf("failed to copy aggregate3 calldata into []CallRequest: %v", err)
+			}
+
+			results := make([]CallResult, len(calls))
+			for i, call := range calls {
+				success, data := handle(call)
+				results[i] = CallResult{Success: success, Data: data}
+			}
+			return packOutputs("aggregate3", toFakeResults(results))
+		},
+	}
+}
+
+func TestNewTypedCall_PacksAndUnpacks(t *testing.T) {
+	testABI, err := abi.JSON(strings.NewReader(testTypedABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse test ABI: %v", err)
+	}
+	target := common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+	call := TypedCall[*big.Int]{
+		Target: target,
+		ABI:    testABI,
+		Method: "getNumber",
+		Args:   []interface{}{big.NewInt(7)},
+	}
+
+	prepared, err := NewTypedCall(call)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prepared.request.Target != target {
+		t.Fatalf("expected target %v, got %v", target, prepared.request.Target)
+	}
+
+	wantReturn, err := testABI.Methods["getNumber"].Outputs.Pack(big.NewInt(42))
+	if err != nil {
+		t.Fatalf("failed to pack expected return: %v", err)
+	}
+	value, err := prepared.unpack(wantReturn)
+	if err != nil {
+		t.Fatalf("unexpected unpack error: %v", err)
+	}
+	got, ok := value.(*big.Int)
+	if !ok || got.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("expected unpacked value 42, got %v", value)
+	}
+}
+
+func TestMulticallTyped_DecodesHomogeneousBatch(t *testing.T) {
+	testABI, err := abi.JSON(strings.NewReader(testTypedABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse test ABI: %v", err)
+	}
+	target := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	calls := []TypedCall[*big.Int]{
+		{Target: target, ABI: testABI, Method: "getNumber", Args: []interface{}{big.NewInt(1)}},
+		{Target: target, ABI: testABI, Method: "getNumber", Args: []interface{}{big.NewInt(2)}},
+	}
+
+	wants := []*big.Int{big.NewInt(10), big.NewInt(20)}
+	callIndex := 0
+	client := aggregate3Fake(t, func(CallRequest) (bool, []byte) {
+		data, err := testABI.Methods["getNumber"].Outputs.Pack(wants[callIndex])
+		if err != nil {
+			t.Fatalf("failed to pack fake return: %v", err)
+		}
+		callIndex++
+		return true, data
+	})
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x00000000000000000000000000000000000009"))
+	results, err := MulticallTyped(cfg, calls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, want := range wants {
+		if !results[i].Success {
+			t.Fatalf("result %d: expected success", i)
+		}
+		if results[i].Err != nil {
+			t.Fatalf("result %d: unexpected decode error: %v", i, results[i].Err)
+		}
+		if results[i].Value.Cmp(want) != 0 {
+			t.Fatalf("result %d: expected value %v, got %v", i, want, results[i].Value)
+		}
+	}
+}
+
+func TestMulticallPrepared_DecodesHeterogeneousBatch(t *testing.T) {
+	testABI, err := abi.JSON(strings.NewReader(testTypedABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse test ABI: %v", err)
+	}
+	target := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	numberCall, err := NewTypedCall(TypedCall[*big.Int]{Target: target, ABI: testABI, Method: "getNumber", Args: []interface{}{big.NewInt(1)}})
+	if err != nil {
+		t.Fatalf("failed to prepare number call: %v", err)
+	}
+	nameCall, err := NewTypedCall(TypedCall[string]{Target: target, ABI: testABI, Method: "getName"})
+	if err != nil {
+		t.Fatalf("failed to prepare name call: %v", err)
+	}
+
+	callIndex := 0
+	client := aggregate3Fake(t, func(CallRequest) (bool, []byte) {
+		defer func() { callIndex++ }()
+		if callIndex == 0 {
+			data, err := testABI.Methods["getNumber"].Outputs.Pack(big.NewInt(99))
+			if err != nil {
+				t.Fatalf("failed to pack fake number return: %v", err)
+			}
+			return true, data
+		}
+		data, err := testABI.Methods["getName"].Outputs.Pack("hello")
+		if err != nil {
+			t.Fatalf("failed to pack fake name return: %v", err)
+		}
+		return true, data
+	})
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x00000000000000000000000000000000000009"))
+	results, err := MulticallPrepared(cfg, []PreparedCall{numberCall, nameCall})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	number, ok := results[0].Value.(*big.Int)
+	if !results[0].Success || results[0].Err != nil || !ok || number.Cmp(big.NewInt(99)) != 0 {
+		t.Fatalf("expected first result to decode to 99, got %+v", results[0])
+	}
+	name, ok := results[1].Value.(string)
+	if !results[1].Success || results[1].Err != nil || !ok || name != "hello" {
+		t.Fatalf("expected second result to decode to %q, got %+v", "hello", results[1])
+	}
+}
+
+func TestMulticallTyped_DecodeFailureSurfacesOnResultErr(t *testing.T) {
+	testABI, err := abi.JSON(strings.NewReader(testTypedABIJSON))
+	if err != nil {
+		t.Fatalf("failed to parse test ABI: %v", err)
+	}
+	target := common.HexToAddress("0x0000000000000000000000000000000000000004")
+
+	calls := []TypedCall[*big.Int]{
+		{Target: target, ABI: testABI, Method: "getNumber", Args: []interface{}{big.NewInt(1)}},
+		{Target: target, ABI: testABI, Method: "getNumber", Args: []interface{}{big.NewInt(2)}},
+	}
+
+	callIndex := 0
+	client := aggregate3Fake(t, func(CallRequest) (bool, []byte) {
+		defer func() { callIndex++ }()
+		if callIndex == 0 {
+			// Malformed return data for getNumber: too short to decode a uint256.
+			return true, []byte{0x01, 0x02}
+		}
+		data, err := testABI.Methods["getNumber"].Outputs.Pack(big.NewInt(55))
+		if err != nil {
+			t.Fatalf("failed to pack fake return: %v", err)
+		}
+		return true, data
+	})
+
+	cfg := NewDefaultMulticallConfig(client, common.HexToAddress("0x00000000000000000000000000000000000009"))
+	results, err := MulticallTyped(cfg, calls)
+	if err != nil {
+		t.Fatalf("expected MulticallTyped to succeed despite a per-call decode failure, got: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if results[0].Err == nil {
+		t.Fatal("expected the first result to carry a decode error")
+	}
+	if results[1].Err != nil {
+		t.Fatalf("expected the second result to decode cleanly, got error: %v", results[1].Err)
+	}
+	if results[1].Value.Cmp(big.NewInt(55)) != 0 {
+		t.Fatalf("expected second result value 55, got %v", results[1].Value)
+	}
+}